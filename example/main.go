@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 	pkgErrors "github.com/pkg/errors"
 	"github.com/alexhornbake/extenderr"
+	"github.com/alexhornbake/extenderr/zapadapter"
 )
 
 // Database/Model layer
@@ -56,18 +57,24 @@ func HandleGetUser() error {
 // HandleRequest shows how a router might call an opinionate handler
 // how a logger might handle that error
 // and how the opinionated handler might write the error response
-func HandleRequest(logger *zap.SugaredLogger) {
+func HandleRequest(logger *zap.Logger) {
 	err := HandleGetUser()
 	if err != nil {
-		// Middleware can use the error message, tags, and stack trace to log a detailed message
-		tags := extenderr.Tags(err)
-		stackTrace := fmt.Sprintf("%+v", err)
 		httpStatus := extenderr.HttpStatus(err)
 		errorCode := extenderr.ErrorCode(err)
 		humanMessage := extenderr.HumanMessage(err)
 
-		tags = append(tags, "stack_trace", stackTrace, "http_status", httpStatus, "error_code", errorCode, "human_message", humanMessage)
-		logger.Errorw(err.Error(), tags...)
+		// WithContext attaches the stack trace, http status, error code and human
+		// message as context fields, and zapadapter.ToZapFields turns the context
+		// fields straight into []zap.Field for the logger call below (tags attached
+		// with WithTags are not included).
+		err = extenderr.WithContext(err, map[string]interface{}{
+			"stack_trace":   fmt.Sprintf("%+v", err),
+			"http_status":   httpStatus,
+			"error_code":    errorCode,
+			"human_message": humanMessage,
+		})
+		logger.Error(err.Error(), zapadapter.ToZapFields(err)...)
 
 		// the http response writer can put together a response that both a client and human can read
 		// (and that is safe to expose to the end user)
@@ -81,8 +88,6 @@ func HandleRequest(logger *zap.SugaredLogger) {
 func main() {
 	logger, _ := zap.NewProduction()
 	defer logger.Sync() // flushes buffer, if any
-	sugar := logger.Sugar()
 
-	HandleRequest(sugar)
-	
+	HandleRequest(logger)
 }
\ No newline at end of file
@@ -6,19 +6,40 @@
 //     - a description that is safe to expose to humans (humanMessage interface)
 //     - an enum error code that can be checked (errorCoder interface)
 //     - an HTTP status code that can be returned (httpStatuser interface)
+//     - a gRPC status code that can be returned (grpcStatuser interface)
 //     - key/value pairs that have been attached (tagger interface)
+//     - whether the error should be forwarded to an error reporter (reporter interface)
+//     - strongly-typed key/value context (contexter interface)
+//     - whether retrying the operation might succeed, and how long to wait (retryable interface)
+//     - a native stack trace captured at wrap time (stackTracer interface)
 //
 // All of the interfaces are private, but considered stable, such that if your
 // use case deviates from this package, one should be able to implement the interface
 // in a similar way that this package implements "Error", "Format", Cause", and "Unwrap".
 //
+// Join combines several errors into one tree-shaped chain (go1.20 errors.Join style).
+// HumanMessage, ErrorCode, HttpStatus, and GrpcStatus return the first non-zero value
+// found walking the tree pre-order; Tags and TagMap collect across every branch.
+//
+// Kind classifies an error into a well-known category (KindNotFound, KindInternal, ...).
+// A single WithKind call auto-populates sensible HttpStatus, GrpcStatus, and HumanMessage
+// defaults, and makes errors.Is(err, extenderr.KindNotFound) work anywhere in the chain,
+// replacing the common pattern of stacking three separate wrappers by hand.
+//
 // This package is safe to use on any error (and nil), it will return "zero" values for any unused
 // fields, or any unimplimented interfaces when retrieving annotations.
 package extenderr
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
 )
 
 // wrapper is the go.13 errors interface for Unwrapping an error
@@ -27,6 +48,12 @@ type wrapper interface {
 	Unwrap() error
 }
 
+// multiWrapper is the go1.20 errors interface for unwrapping an error into several
+// branches (errors.Join). This interface is used to walk tree-shaped error chains.
+type multiWrapper interface {
+	Unwrap() []error
+}
+
 /*
 
  HumanMessage
@@ -63,14 +90,15 @@ func WithHumanMessage(err error, message string) error {
 		return err
 	}
 	return &withHumanMessage{
-		error: err,
+		error: withStackIfMissing(err),
 		message: message,
 	}
 }
 
 
 // HumanMessage returns the first (outter most) message encountered in the error chain.
-// The message is intended to be exposed to human. If not message exists or the error is nil
+// The message is intended to be exposed to human. If no message exists, it falls back
+// to the chain's Kind default (see WithKind). If neither exists, or the error is nil,
 // it returns empty string.
 func HumanMessage(errToWalk error) string {
 	message := ""
@@ -83,6 +111,9 @@ func HumanMessage(errToWalk error) string {
 		}
 		return message != ""
 	})
+	if message == "" {
+		message = KindOf(errToWalk).HumanMessage()
+	}
 	return message
 }
 
@@ -121,7 +152,7 @@ func WithErrorCode(err error, errorCode int) error {
 		return err
 	}
 	return &withErrorCode{
-		error: err,
+		error: withStackIfMissing(err),
 		errorCode: errorCode,
 	}
 }
@@ -178,12 +209,13 @@ func WithHttpStatus(err error, status int) error {
 		return err
 	}
 	return &withHttpStatus{
-		error: err,
+		error: withStackIfMissing(err),
 		httpStatus: status,
 	}
 }
 
 // HttpStatus returns the first (outter most) http status code encountered in the error chain.
+// Falls back to the chain's Kind default (see WithKind) if none is set explicitly.
 func HttpStatus(errToWalk error) int {
 	status := 0
 	if errToWalk == nil {
@@ -195,11 +227,248 @@ func HttpStatus(errToWalk error) int {
 		}
 		return status != 0
 	})
+	if status == 0 {
+		status = KindOf(errToWalk).HttpStatus()
+	}
 	return status
 }
 
 /*
 
+Grpc Status
+
+*/
+
+type grpcStatuser interface {
+	GrpcStatus() codes.Code
+}
+
+type withGrpcStatus struct{
+	error
+	grpcStatus codes.Code
+}
+
+// GrpcStatus returns a gRPC status code that can be returned to a client
+func (e *withGrpcStatus) GrpcStatus() codes.Code { return e.grpcStatus }
+
+// Unwrap returns the error that is being wrapped
+func (e *withGrpcStatus) Unwrap() error { return e.error}
+
+// Error implements the Error interface
+func (e *withGrpcStatus) Error() string {
+	return fmt.Sprintf("grpc status %s : ", e.grpcStatus) + e.Unwrap().Error()
+}
+
+// Format implements the Formatter interface
+func (e *withGrpcStatus) Format(s fmt.State, verb rune) { format(e, s, verb) }
+
+// WithGrpcStatus wraps the error with a gRPC status code.
+func WithGrpcStatus(err error, code codes.Code) error {
+	if err == nil {
+		return err
+	}
+	return &withGrpcStatus{
+		error: withStackIfMissing(err),
+		grpcStatus: code,
+	}
+}
+
+// GrpcStatus returns the first (outter most) gRPC status code encountered in the error chain.
+// Falls back to the chain's Kind default (see WithKind) if none is set explicitly.
+func GrpcStatus(errToWalk error) codes.Code {
+	status := codes.OK
+	if errToWalk == nil {
+		return status
+	}
+	walkErrorChain(errToWalk, func(err error) bool {
+		if gs, ok := err.(grpcStatuser); ok {
+			status = gs.GrpcStatus()
+		}
+		return status != codes.OK
+	})
+	if status == codes.OK {
+		if kind := KindOf(errToWalk); kind != "" {
+			status = kind.GrpcCode()
+		}
+	}
+	return status
+}
+
+// httpToGrpcStatus maps the HTTP status codes this package's constructors commonly
+// use onto their closest gRPC equivalent, per the standard mapping used by grpc-gateway.
+var httpToGrpcStatus = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// grpcToHttpStatus is the inverse of httpToGrpcStatus.
+var grpcToHttpStatus = map[codes.Code]int{
+	codes.InvalidArgument:   http.StatusBadRequest,
+	codes.Unauthenticated:   http.StatusUnauthorized,
+	codes.PermissionDenied:  http.StatusForbidden,
+	codes.NotFound:          http.StatusNotFound,
+	codes.AlreadyExists:     http.StatusConflict,
+	codes.ResourceExhausted: http.StatusTooManyRequests,
+	codes.Internal:          http.StatusInternalServerError,
+	codes.Unavailable:       http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+}
+
+// GrpcStatusFromHttp infers a gRPC status code from the HttpStatus already attached
+// to the error chain, for servers that need to surface the same wrapped error from
+// both an HTTP handler and a gRPC server without re-annotating it. Returns codes.Unknown
+// if the chain has no http status, or it doesn't map onto a known gRPC code.
+func GrpcStatusFromHttp(err error) codes.Code {
+	if code, ok := httpToGrpcStatus[HttpStatus(err)]; ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+// HttpStatusFromGrpc infers an HTTP status code from the GrpcStatus already attached
+// to the error chain. Returns http.StatusInternalServerError if the chain has no grpc
+// status, or it doesn't map onto a known HTTP status.
+func HttpStatusFromGrpc(err error) int {
+	if status, ok := grpcToHttpStatus[GrpcStatus(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+/*
+
+Retryable
+
+*/
+
+type retryable interface {
+	Retryable() bool
+}
+
+type retryAfterer interface {
+	RetryAfter() time.Duration
+}
+
+type withRetryable struct{
+	error
+	retryable bool
+}
+
+// Retryable returns whether the caller can expect a retry of the same operation to succeed.
+func (e *withRetryable) Retryable() bool { return e.retryable }
+
+// Unwrap returns the error that is being wrapped
+func (e *withRetryable) Unwrap() error { return e.error}
+
+// Error implements the Error interface
+func (e *withRetryable) Error() string { return e.Unwrap().Error() }
+
+// Format implements the Formatter interface
+func (e *withRetryable) Format(s fmt.State, verb rune) { format(e, s, verb) }
+
+// WithRetryable marks the error as retryable (or not), for callers that know better
+// than the default inference in Retryable.
+func WithRetryable(err error, retryable bool) error {
+	if err == nil {
+		return err
+	}
+	return &withRetryable{
+		error: withStackIfMissing(err),
+		retryable: retryable,
+	}
+}
+
+type withRetryAfter struct{
+	error
+	retryAfter time.Duration
+}
+
+// RetryAfter returns how long the caller should wait before retrying.
+func (e *withRetryAfter) RetryAfter() time.Duration { return e.retryAfter }
+
+// Unwrap returns the error that is being wrapped
+func (e *withRetryAfter) Unwrap() error { return e.error}
+
+// Error implements the Error interface
+func (e *withRetryAfter) Error() string { return e.Unwrap().Error() }
+
+// Format implements the Formatter interface
+func (e *withRetryAfter) Format(s fmt.State, verb rune) { format(e, s, verb) }
+
+// WithRetryAfter attaches a backoff hint to the error, for HTTP middleware to set as a
+// Retry-After header, or gRPC middleware to translate into a retry info detail.
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return err
+	}
+	return &withRetryAfter{
+		error: withStackIfMissing(err),
+		retryAfter: d,
+	}
+}
+
+// retryableHttpStatus and retryableGrpcCode are the statuses Retryable infers as
+// retryable when no error in the chain says so explicitly.
+var retryableHttpStatus = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+var retryableGrpcCode = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.ResourceExhausted: true,
+	codes.Aborted:           true,
+}
+
+// Retryable returns the first (outter most) retryable flag encountered in the error
+// chain. If none is set explicitly, it is inferred from the HTTP/gRPC status already
+// attached to the chain.
+func Retryable(errToWalk error) bool {
+	if errToWalk == nil {
+		return false
+	}
+	isRetryable := false
+	found := false
+	walkErrorChain(errToWalk, func(err error) bool {
+		if r, ok := err.(retryable); ok {
+			isRetryable = r.Retryable()
+			found = true
+		}
+		return found
+	})
+	if found {
+		return isRetryable
+	}
+	return retryableHttpStatus[HttpStatus(errToWalk)] || retryableGrpcCode[GrpcStatus(errToWalk)]
+}
+
+// RetryAfter returns the first (outter most) retry-after duration encountered in the
+// error chain. Returns 0 if none is set.
+func RetryAfter(errToWalk error) time.Duration {
+	var retryAfter time.Duration
+	if errToWalk == nil {
+		return retryAfter
+	}
+	walkErrorChain(errToWalk, func(err error) bool {
+		if ra, ok := err.(retryAfterer); ok {
+			retryAfter = ra.RetryAfter()
+		}
+		return retryAfter != 0
+	})
+	return retryAfter
+}
+
+/*
+
 Tags
 
 */
@@ -232,7 +501,7 @@ func WithTags(err error, keysAndValues ...interface{}) error {
 		return err
 	}
 	return &withTags{
-		error: err,
+		error: withStackIfMissing(err),
 		keysAndValues: keysAndValues,
 	}
 }
@@ -251,7 +520,7 @@ func Tags(errToWalk error) []interface{} {
 
 // TagMap will return a map of all tags in the error chain.
 // This is a best effort, unblanced key pairs will be made even,
-// and duplicate tags overwritten (the inner most tag wins).
+// and duplicate tags overwritten (the outer most tag wins).
 func TagMap(errToWalk error) map[interface{}]interface{} {
 	allTags := map[interface{}]interface{}{}
 	walkErrorChain(errToWalk, func(err error) bool {
@@ -261,7 +530,9 @@ func TagMap(errToWalk error) map[interface{}]interface{} {
 				tags = append(tags, "unbalanced tag")
 			}
 			for i:=0; i<len(tags); i=i+2 {
-				allTags[tags[i]] = tags[i+1]
+				if _, exists := allTags[tags[i]]; !exists {
+					allTags[tags[i]] = tags[i+1]
+				}
 			}
 		}
 		return false
@@ -269,21 +540,488 @@ func TagMap(errToWalk error) map[interface{}]interface{} {
 	return allTags
 }
 
+/*
+
+Report
+
+*/
+
+type reporter interface {
+	Reportable() bool
+}
+
+type withReport struct{
+	error
+	reportable bool
+}
+
+// Reportable returns whether this error should be forwarded to an error reporter.
+func (e *withReport) Reportable() bool { return e.reportable }
+
+// Unwrap returns the error that is being wrapped
+func (e *withReport) Unwrap() error { return e.error}
+
+// Error implements the Error interface
+func (e *withReport) Error() string { return e.Unwrap().Error() }
+
+// Format implements the Formatter interface
+func (e *withReport) Format(s fmt.State, verb rune) { format(e, s, verb) }
+
+// WithReport marks the error as reportable (or not) to an external error reporter
+// (Sentry, Rollbar, Cloud Error Reporting, etc). This lets middleware separate
+// user-facing errors (4xx, don't page) from system errors (5xx, do page).
+func WithReport(err error, reportable bool) error {
+	if err == nil {
+		return err
+	}
+	return &withReport{
+		error: withStackIfMissing(err),
+		reportable: reportable,
+	}
+}
+
+// Reportable returns the first (outter most) reportable flag encountered in the error chain.
+// Errors with no WithReport annotation are not reportable.
+func Reportable(errToWalk error) bool {
+	reportable := false
+	if errToWalk == nil {
+		return reportable
+	}
+	found := false
+	walkErrorChain(errToWalk, func(err error) bool {
+		if r, ok := err.(reporter); ok {
+			reportable = r.Reportable()
+			found = true
+		}
+		return found
+	})
+	return reportable
+}
+
+// Reporter forwards a reportable error to an external system.
+type Reporter interface {
+	Report(ctx context.Context, err error)
+}
+
+// Report dispatches err to each of the given reporters, which can use StackTrace, TagMap,
+// HumanMessage, HttpStatus, and GrpcStatus to pull whatever context they need out of the
+// chain. If err is not Reportable, Report is a no-op.
+func Report(ctx context.Context, err error, reporters ...Reporter) {
+	if err == nil || !Reportable(err) {
+		return
+	}
+	for _, r := range reporters {
+		r.Report(ctx, err)
+	}
+}
+
+/*
+
+Context
+
+*/
+
+type contexter interface {
+	Fields() map[string]interface{}
+}
+
+type withContext struct{
+	error
+	fields map[string]interface{}
+}
+
+// Fields returns the strongly-typed key/value context attached to this error.
+func (e *withContext) Fields() map[string]interface{} { return e.fields }
+
+// Unwrap returns the error that is being wrapped
+func (e *withContext) Unwrap() error { return e.error}
+
+// Error implements the Error interface
+func (e *withContext) Error() string { return e.Unwrap().Error() }
+
+// Format implements the Formatter interface
+func (e *withContext) Format(s fmt.State, verb rune) { format(e, s, verb) }
+
+// WithContext wraps the error with strongly-typed key/value context, distinct from
+// the untyped key/value pairs attached by WithTags.
+func WithContext(err error, fields map[string]interface{}) error {
+	if err == nil {
+		return err
+	}
+	return &withContext{
+		error: withStackIfMissing(err),
+		fields: fields,
+	}
+}
+
+// WithField wraps the error with a single key/value context field. It's shorthand
+// for WithContext(err, map[string]interface{}{key: value}).
+func WithField(err error, key string, value interface{}) error {
+	return WithContext(err, map[string]interface{}{key: value})
+}
+
+// Fields returns a merged map of all context fields in the error chain. On key
+// collision, the outer wrap (the one closest to the error returned to the caller)
+// wins, since it was attached with the most context about what went wrong.
+func Fields(errToWalk error) map[string]interface{} {
+	allFields := map[string]interface{}{}
+	walkErrorChain(errToWalk, func(err error) bool {
+		if f, ok := err.(contexter); ok {
+			for k, v := range f.Fields() {
+				if _, exists := allFields[k]; !exists {
+					allFields[k] = v
+				}
+			}
+		}
+		return false
+	})
+	return allFields
+}
+
+/*
+
+Stack
+
+*/
+
+// CaptureStack controls whether WithHumanMessage, WithErrorCode, WithHttpStatus, and WithTags
+// also attach a stack trace, for callers who want pkg/errors-style "%+v" output without having
+// to call WithStack explicitly. It only attaches a stack when the chain doesn't already have one.
+var CaptureStack = false
+
+// stackDepth bounds how many frames WithStack captures. This is generous enough for
+// application code without walking off into runtime/testing machinery on most stacks.
+const stackDepth = 32
+
+type stackTracer interface {
+	StackTrace() []runtime.Frame
+}
+
+type withStack struct {
+	error
+	pcs []uintptr
+}
+
+// StackTrace resolves the captured program counters into runtime.Frame values.
+// Resolution is lazy (done here, not at capture time) since runtime.CallersFrames
+// is only cheap to build, not to walk, and most errors are never printed with "%+v".
+func (e *withStack) StackTrace() []runtime.Frame {
+	frames := runtime.CallersFrames(e.pcs)
+	trace := make([]runtime.Frame, 0, len(e.pcs))
+	for {
+		frame, more := frames.Next()
+		trace = append(trace, frame)
+		if !more {
+			break
+		}
+	}
+	return trace
+}
+
+// Unwrap returns the error that is being wrapped
+func (e *withStack) Unwrap() error { return e.error }
+
+// Error implements the Error interface
+func (e *withStack) Error() string { return e.Unwrap().Error() }
+
+// Format implements the Formatter interface, printing the resolved stack frames
+// after the error chain when the caller asks for "%+v", pkg/errors style.
+func (e *withStack) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+	if verb == 'v' && s.Flag('+') {
+		for _, frame := range e.StackTrace() {
+			fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+	}
+}
+
+// captureStack builds the withStack wrapper, skipping skip stack frames before
+// recording, following runtime.Callers' own convention (skip=0 is runtime.Callers
+// itself, skip=1 captureStack, skip=2 its caller, and so on). Callers of this helper pass a skip that
+// accounts for their own distance from the real call site, so WithStack and
+// withStackIfMissing - which has one extra frame of indirection - land on the
+// same real caller instead of pointing at each other's internals.
+func captureStack(err error, skip int) error {
+	if err == nil || StackTrace(err) != nil {
+		return err
+	}
+	pcs := make([]uintptr, stackDepth)
+	n := runtime.Callers(skip, pcs)
+	return &withStack{
+		error: err,
+		pcs:   pcs[:n],
+	}
+}
+
+// WithStack wraps the error with a stack trace captured at this call site, using
+// runtime.Callers. If the chain already has a stack attached, it is left alone -
+// the wrap nearest the root cause is the one worth keeping.
+func WithStack(err error) error {
+	return captureStack(err, 3)
+}
+
+// StackTrace returns the first (innermost, closest to the root cause) stack trace
+// encountered in the error chain. Returns nil if no error in the chain captured one.
+func StackTrace(errToWalk error) []runtime.Frame {
+	var trace []runtime.Frame
+	if errToWalk == nil {
+		return trace
+	}
+	walkErrorChain(errToWalk, func(err error) bool {
+		if st, ok := err.(stackTracer); ok {
+			trace = st.StackTrace()
+		}
+		return false
+	})
+	return trace
+}
+
+// withStackIfMissing attaches a stack trace to err when CaptureStack is enabled and
+// the chain doesn't already have one further down. Used by the other annotation
+// constructors so enabling CaptureStack is enough to get traces without remembering
+// to call WithStack everywhere. Captured one frame deeper than WithStack, since the
+// With* constructor calling this sits between it and the real call site.
+func withStackIfMissing(err error) error {
+	if !CaptureStack {
+		return err
+	}
+	return captureStack(err, 4)
+}
+
+/*
+
+Join
+
+*/
+
+type joinError struct {
+	errs []error
+}
+
+// Error implements the Error interface
+func (e *joinError) Error() string {
+	messages := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the branches that are being joined, go1.20 multi-error style.
+// This is what lets errors.Is/errors.As and walkErrorChain see every branch.
+func (e *joinError) Unwrap() []error { return e.errs }
+
+// Format implements the Formatter interface
+func (e *joinError) Format(s fmt.State, verb rune) { format(e, s, verb) }
+
+// Join combines several errors into one, compatible with errors.Is, errors.As, and
+// Unwrap() []error (go1.20's errors.Join). Nil errors are discarded; if every error
+// is nil, Join returns nil. Unlike the other With* wrappers, a joined error has no
+// single chain - HumanMessage, ErrorCode, HttpStatus, and GrpcStatus return the first
+// non-zero value found across the branches in a pre-order walk, while Tags and TagMap
+// collect tags from every branch.
+func Join(errs ...error) error {
+	nonNil := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return &joinError{errs: nonNil}
+}
+
+/*
+
+Kind
+
+*/
+
+// Kind classifies an error into one of a small set of well-known categories, so that
+// errors.Is(err, extenderr.KindNotFound) works anywhere in the chain, and a single
+// WithKind call can stand in for stacking WithHttpStatus/WithGrpcStatus/WithHumanMessage
+// by hand. Kind implements error so it can be used as an errors.Is target directly.
+type Kind string
+
+// The well-known kinds. Each has sensible defaults for HttpStatus, GrpcCode, and
+// HumanMessage - see the methods below.
+const (
+	KindNotFound          Kind = "not_found"
+	KindPermissionDenied  Kind = "permission_denied"
+	KindInvalidArgument   Kind = "invalid_argument"
+	KindAlreadyExists     Kind = "already_exists"
+	KindUnauthenticated   Kind = "unauthenticated"
+	KindResourceExhausted Kind = "resource_exhausted"
+	KindUnavailable       Kind = "unavailable"
+	KindInternal          Kind = "internal"
+)
+
+// Error implements the error interface, so a Kind can be passed as the target to errors.Is.
+func (k Kind) Error() string { return string(k) }
+
+// HttpStatus returns the default HTTP status code for the kind, or 0 if the kind is
+// empty or unrecognized.
+func (k Kind) HttpStatus() int {
+	switch k {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindPermissionDenied:
+		return http.StatusForbidden
+	case KindInvalidArgument:
+		return http.StatusBadRequest
+	case KindAlreadyExists:
+		return http.StatusConflict
+	case KindUnauthenticated:
+		return http.StatusUnauthorized
+	case KindResourceExhausted:
+		return http.StatusTooManyRequests
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	case KindInternal:
+		return http.StatusInternalServerError
+	default:
+		return 0
+	}
+}
+
+// GrpcCode returns the default gRPC status code for the kind, or codes.Unknown if the
+// kind is empty or unrecognized.
+func (k Kind) GrpcCode() codes.Code {
+	switch k {
+	case KindNotFound:
+		return codes.NotFound
+	case KindPermissionDenied:
+		return codes.PermissionDenied
+	case KindInvalidArgument:
+		return codes.InvalidArgument
+	case KindAlreadyExists:
+		return codes.AlreadyExists
+	case KindUnauthenticated:
+		return codes.Unauthenticated
+	case KindResourceExhausted:
+		return codes.ResourceExhausted
+	case KindUnavailable:
+		return codes.Unavailable
+	case KindInternal:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// HumanMessage returns a default human-readable message for the kind, or empty string
+// if the kind is empty or unrecognized.
+func (k Kind) HumanMessage() string {
+	switch k {
+	case KindNotFound:
+		return "the requested resource could not be found"
+	case KindPermissionDenied:
+		return "you do not have permission to do that"
+	case KindInvalidArgument:
+		return "the request was invalid"
+	case KindAlreadyExists:
+		return "the resource already exists"
+	case KindUnauthenticated:
+		return "authentication is required"
+	case KindResourceExhausted:
+		return "too many requests, please try again later"
+	case KindUnavailable:
+		return "the service is temporarily unavailable"
+	case KindInternal:
+		return "something went wrong"
+	default:
+		return ""
+	}
+}
+
+type kinder interface {
+	Kind() Kind
+}
+
+type withKind struct{
+	error
+	kind Kind
+}
+
+// Kind returns the Kind this error was classified with.
+func (e *withKind) Kind() Kind { return e.kind }
+
+// Is reports whether target is the Kind this error was classified with, so
+// errors.Is(err, extenderr.KindNotFound) finds a WithKind(err, extenderr.KindNotFound)
+// anywhere in the chain.
+func (e *withKind) Is(target error) bool {
+	k, ok := target.(Kind)
+	return ok && k == e.kind
+}
+
+// Unwrap returns the error that is being wrapped
+func (e *withKind) Unwrap() error { return e.error}
+
+// Error implements the Error interface
+func (e *withKind) Error() string { return string(e.kind) + ": " + e.Unwrap().Error() }
+
+// Format implements the Formatter interface
+func (e *withKind) Format(s fmt.State, verb rune) { format(e, s, verb) }
+
+// WithKind classifies the error with a Kind. HttpStatus, GrpcStatus, and HumanMessage
+// fall back to the kind's defaults when the chain has no explicit WithHttpStatus,
+// WithGrpcStatus, or WithHumanMessage of its own, so a single WithKind call replaces
+// stacking all three by hand while still being overridable for advanced cases.
+func WithKind(err error, kind Kind) error {
+	if err == nil {
+		return err
+	}
+	return &withKind{
+		error: withStackIfMissing(err),
+		kind: kind,
+	}
+}
+
+// KindOf returns the first (outter most) Kind encountered in the error chain, or ""
+// if the chain was never classified with WithKind.
+func KindOf(errToWalk error) Kind {
+	var kind Kind
+	if errToWalk == nil {
+		return kind
+	}
+	walkErrorChain(errToWalk, func(err error) bool {
+		if k, ok := err.(kinder); ok {
+			kind = k.Kind()
+		}
+		return kind != ""
+	})
+	return kind
+}
+
 // helper to format a wrapped error
 // compatible with pkg/errors "%+v" convention for stack traces
 func format(err error, s fmt.State, verb rune) {
-	w, ok := err.(wrapper)
-	if !ok {
-		io.WriteString(s, err.Error())
-		return
-	}
-	switch verb {
-	case 'v':
-		if s.Flag('+') {
-			fmt.Fprintf(s, "%+v\n", w.Unwrap())
+	switch w := err.(type) {
+	case multiWrapper:
+		switch verb {
+		case 'v':
+			if s.Flag('+') {
+				for _, branch := range w.Unwrap() {
+					fmt.Fprintf(s, "%+v\n", branch)
+				}
+			}
+			fallthrough
+		case 's', 'q':
+			io.WriteString(s, err.Error())
+		}
+	case wrapper:
+		switch verb {
+		case 'v':
+			if s.Flag('+') {
+				fmt.Fprintf(s, "%+v\n", w.Unwrap())
+			}
+			fallthrough
+		case 's', 'q':
+			io.WriteString(s, err.Error())
 		}
-		fallthrough
-	case 's', 'q':
+	default:
 		io.WriteString(s, err.Error())
 	}
 }
@@ -293,22 +1031,28 @@ func format(err error, s fmt.State, verb rune) {
 // it should return true to stop walking the chain.
 type errorIterator func(error) bool
 
-// walkErrorChain will walk the error chain and run the
-// errorIterator on every error in the chain unless
-// the errorIterator returns true to signal an early return.
+// walkErrorChain will walk the error chain, pre-order, and run the errorIterator on
+// every error encountered unless the errorIterator returns true to signal an early
+// return. Chains produced by Join unwrap into several branches (multiWrapper); each
+// branch is walked in order before moving on to the next.
 func walkErrorChain(err error, f errorIterator) bool {
 	if err == nil {
 		return false
 	}
-	for err != nil {
-		if f(err) {
-			return true
-		}
-		w, ok := err.(wrapper)
-		if !ok {
-			break
+	if f(err) {
+		return true
+	}
+	switch w := err.(type) {
+	case multiWrapper:
+		for _, branch := range w.Unwrap() {
+			if walkErrorChain(branch, f) {
+				return true
+			}
 		}
-		err = w.Unwrap()
+		return false
+	case wrapper:
+		return walkErrorChain(w.Unwrap(), f)
+	default:
+		return false
 	}
-	return f(err)
 }
@@ -1,9 +1,16 @@
 package extenderr
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"github.com/pkg/errors"
+	"strings"
+	"testing"
+	"time"
+
+	pkgErrors "github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
 	//"go.uber.org/zap"
 )
 
@@ -13,13 +20,13 @@ const (
 
 
 func doSomethingElse() error {
-	return errors.New("the root cause")
+	return pkgErrors.New("the root cause")
 }
 
 func doSomething() error {
 	err := doSomethingElse()
 	if err != nil {
-		wrapped := errors.Wrap(WithErrorCode(err, SuperBadErrorCode), "something bad happened")
+		wrapped := pkgErrors.Wrap(WithErrorCode(err, SuperBadErrorCode), "something bad happened")
 		return WithHttpStatus(wrapped, http.StatusInternalServerError)
 	}
 	return nil
@@ -36,7 +43,7 @@ func doSomethingWithTags() error {
 
 func ExampleNamedCause() {
 	err := doSomething()
-	err = errors.Wrap(err, "more context")
+	err = pkgErrors.Wrap(err, "more context")
 	err2 := doSomethingWithTags()
 	
 	// Print the ints that we attached
@@ -61,4 +68,266 @@ func ExampleNamedCause() {
 	// Output: 1 500
 	// more context: http status 500 : something bad happened: error code 1 : the root cause
 	// map[user:beazley user_id:666]
+}
+
+func TestWithStack(t *testing.T) {
+	root := pkgErrors.New("the root cause")
+	err := WithStack(root)
+
+	trace := StackTrace(err)
+	if len(trace) == 0 {
+		t.Fatalf("expected at least one frame, got none")
+	}
+	if !strings.Contains(trace[0].Function, "TestWithStack") {
+		t.Errorf("expected innermost frame to be TestWithStack, got %q", trace[0].Function)
+	}
+
+	formatted := fmt.Sprintf("%+v", err)
+	if !strings.Contains(formatted, "TestWithStack") {
+		t.Errorf("expected %%+v output to contain the captured stack, got %q", formatted)
+	}
+}
+
+func TestWithStack_doesNotDoubleWrap(t *testing.T) {
+	err := WithStack(pkgErrors.New("the root cause"))
+	wrapped := WithStack(err)
+
+	if wrapped != err {
+		t.Errorf("expected WithStack to leave an existing stack alone")
+	}
+}
+
+func TestWithStack_nil(t *testing.T) {
+	if WithStack(nil) != nil {
+		t.Errorf("expected WithStack(nil) to return nil")
+	}
+	if StackTrace(nil) != nil {
+		t.Errorf("expected StackTrace(nil) to return nil")
+	}
+}
+
+func TestGrpcStatus(t *testing.T) {
+	err := WithGrpcStatus(pkgErrors.New("the root cause"), codes.NotFound)
+	if GrpcStatus(err) != codes.NotFound {
+		t.Errorf("expected codes.NotFound, got %v", GrpcStatus(err))
+	}
+}
+
+func TestGrpcStatusFromHttp(t *testing.T) {
+	err := WithHttpStatus(pkgErrors.New("the root cause"), http.StatusNotFound)
+	if code := GrpcStatusFromHttp(err); code != codes.NotFound {
+		t.Errorf("expected codes.NotFound, got %v", code)
+	}
+}
+
+func TestHttpStatusFromGrpc(t *testing.T) {
+	err := WithGrpcStatus(pkgErrors.New("the root cause"), codes.PermissionDenied)
+	if status := HttpStatusFromGrpc(err); status != http.StatusForbidden {
+		t.Errorf("expected %d, got %d", http.StatusForbidden, status)
+	}
+}
+
+type recordingReporter struct {
+	reported []error
+}
+
+func (r *recordingReporter) Report(ctx context.Context, err error) {
+	r.reported = append(r.reported, err)
+}
+
+func TestReportable(t *testing.T) {
+	err := pkgErrors.New("the root cause")
+	if Reportable(err) {
+		t.Errorf("expected an unannotated error to not be reportable")
+	}
+
+	err = WithReport(err, true)
+	if !Reportable(err) {
+		t.Errorf("expected WithReport(err, true) to be reportable")
+	}
+}
+
+func TestReport(t *testing.T) {
+	reporter := &recordingReporter{}
+
+	Report(context.Background(), WithReport(pkgErrors.New("user error"), false), reporter)
+	if len(reporter.reported) != 0 {
+		t.Errorf("expected a non-reportable error to not be dispatched")
+	}
+
+	err := WithReport(pkgErrors.New("system error"), true)
+	Report(context.Background(), err, reporter)
+	if len(reporter.reported) != 1 || reporter.reported[0] != err {
+		t.Errorf("expected a reportable error to be dispatched to the reporter")
+	}
+}
+
+func TestFields(t *testing.T) {
+	err := WithContext(pkgErrors.New("the root cause"), map[string]interface{}{"user_id": 666, "request_id": "abc"})
+	err = WithField(err, "user_id", 42) // outer wrap, should win on collision
+
+	fields := Fields(err)
+	if fields["user_id"] != 42 {
+		t.Errorf("expected outer field to win, got %v", fields["user_id"])
+	}
+	if fields["request_id"] != "abc" {
+		t.Errorf("expected inner field to still be present, got %v", fields["request_id"])
+	}
+}
+
+func TestJoin(t *testing.T) {
+	dbErr := WithHttpStatus(WithTags(pkgErrors.New("db unavailable"), "backend", "db"), http.StatusServiceUnavailable)
+	cacheErr := WithTags(pkgErrors.New("cache miss"), "backend", "cache")
+
+	joined := Join(dbErr, cacheErr)
+
+	if !errors.Is(joined, dbErr) || !errors.Is(joined, cacheErr) {
+		t.Errorf("expected errors.Is to find both branches")
+	}
+	if HttpStatus(joined) != http.StatusServiceUnavailable {
+		t.Errorf("expected the db branch's http status to be found, got %d", HttpStatus(joined))
+	}
+
+	tags := TagMap(joined)
+	if tags["backend"] != "db" {
+		t.Errorf("expected the outer (first-listed) branch's tag to win, got %v", tags["backend"])
+	}
+}
+
+func TestJoin_formatRecursesIntoBranches(t *testing.T) {
+	a := WithStack(pkgErrors.New("db unavailable"))
+	b := WithStack(pkgErrors.New("cache miss"))
+
+	formatted := fmt.Sprintf("%+v", Join(a, b))
+	if !strings.Contains(formatted, "TestJoin_formatRecursesIntoBranches") {
+		t.Errorf("expected %%+v to recurse into each branch's stack trace, got %q", formatted)
+	}
+}
+
+func TestJoin_nilErrors(t *testing.T) {
+	if Join(nil, nil) != nil {
+		t.Errorf("expected Join of only nils to return nil")
+	}
+
+	err := pkgErrors.New("the root cause")
+	joined := Join(nil, err)
+	if !errors.Is(joined, err) {
+		t.Errorf("expected nil branches to be discarded, not break the join")
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	err := pkgErrors.New("the root cause")
+	if Retryable(err) {
+		t.Errorf("expected an unannotated error to not be retryable")
+	}
+
+	err = WithRetryable(err, true)
+	if !Retryable(err) {
+		t.Errorf("expected WithRetryable(err, true) to be retryable")
+	}
+}
+
+func TestRetryable_inferredFromStatus(t *testing.T) {
+	err := WithHttpStatus(pkgErrors.New("the root cause"), http.StatusServiceUnavailable)
+	if !Retryable(err) {
+		t.Errorf("expected a 503 to be inferred as retryable")
+	}
+
+	err = WithGrpcStatus(pkgErrors.New("the root cause"), codes.ResourceExhausted)
+	if !Retryable(err) {
+		t.Errorf("expected codes.ResourceExhausted to be inferred as retryable")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	err := WithRetryAfter(pkgErrors.New("the root cause"), 5*time.Second)
+	if RetryAfter(err) != 5*time.Second {
+		t.Errorf("expected a 5s retry-after, got %v", RetryAfter(err))
+	}
+}
+
+func TestWithKind(t *testing.T) {
+	err := WithKind(pkgErrors.New("no rows found"), KindNotFound)
+
+	if !errors.Is(err, KindNotFound) {
+		t.Errorf("expected errors.Is to match the attached kind")
+	}
+	if errors.Is(err, KindInternal) {
+		t.Errorf("expected errors.Is to not match an unrelated kind")
+	}
+	if HttpStatus(err) != http.StatusNotFound {
+		t.Errorf("expected HttpStatus to fall back to the kind's default, got %d", HttpStatus(err))
+	}
+	if GrpcStatus(err) != codes.NotFound {
+		t.Errorf("expected GrpcStatus to fall back to the kind's default, got %v", GrpcStatus(err))
+	}
+	if HumanMessage(err) == "" {
+		t.Errorf("expected HumanMessage to fall back to the kind's default")
+	}
+}
+
+func TestWithKind_explicitOverride(t *testing.T) {
+	err := WithKind(pkgErrors.New("no rows found"), KindNotFound)
+	err = WithHttpStatus(err, http.StatusTeapot)
+
+	if HttpStatus(err) != http.StatusTeapot {
+		t.Errorf("expected an explicit WithHttpStatus to override the kind's default, got %d", HttpStatus(err))
+	}
+}
+
+func TestKindOf_unset(t *testing.T) {
+	err := pkgErrors.New("the root cause")
+	if KindOf(err) != "" {
+		t.Errorf("expected KindOf to be empty for an unclassified error")
+	}
+	if HttpStatus(err) != 0 {
+		t.Errorf("expected HttpStatus to stay 0 with no kind and no explicit status")
+	}
+}
+
+// customKindErr is a caller-defined error implementing the kinder interface directly,
+// without going through WithKind. KindOf (and the HttpStatus/GrpcStatus/HumanMessage
+// fallback) should recognize it the same way it recognizes *withKind.
+type customKindErr struct {
+	kind Kind
+}
+
+func (e *customKindErr) Error() string { return "custom: " + string(e.kind) }
+func (e *customKindErr) Kind() Kind    { return e.kind }
+
+func TestKindOf_customImplementation(t *testing.T) {
+	err := &customKindErr{kind: KindUnavailable}
+
+	if KindOf(err) != KindUnavailable {
+		t.Errorf("expected KindOf to recognize a caller's own kinder implementation, got %q", KindOf(err))
+	}
+	if HttpStatus(err) != http.StatusServiceUnavailable {
+		t.Errorf("expected HttpStatus to fall back through a caller's own kinder implementation, got %d", HttpStatus(err))
+	}
+}
+
+func TestCaptureStack(t *testing.T) {
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	err := WithTags(pkgErrors.New("the root cause"), "key", "value")
+	if StackTrace(err) == nil {
+		t.Errorf("expected CaptureStack to attach a stack trace")
+	}
+}
+
+func TestCaptureStack_pointsAtTheRealCallSite(t *testing.T) {
+	CaptureStack = true
+	defer func() { CaptureStack = false }()
+
+	err := WithHumanMessage(pkgErrors.New("the root cause"), "oops")
+
+	trace := StackTrace(err)
+	if len(trace) == 0 {
+		t.Fatalf("expected at least one frame, got none")
+	}
+	if !strings.Contains(trace[0].Function, "TestCaptureStack_pointsAtTheRealCallSite") {
+		t.Errorf("expected the innermost frame to be this test, not a library internal, got %q", trace[0].Function)
+	}
 }
\ No newline at end of file
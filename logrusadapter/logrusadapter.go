@@ -0,0 +1,22 @@
+// package logrusadapter converts an extenderr error's context fields into the
+// logrus.Fields a logrus Entry's WithFields expects, so callers don't have to
+// build that map by hand from extenderr.Fields. Kept as a separate package so
+// the core extenderr package does not depend on logrus.
+package logrusadapter
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/alexhornbake/extenderr"
+)
+
+// ToLogrusFields returns the error's context fields (see extenderr.Fields) as
+// logrus.Fields, ready to pass to a logrus Entry's WithFields call.
+func ToLogrusFields(err error) logrus.Fields {
+	fields := extenderr.Fields(err)
+	logrusFields := make(logrus.Fields, len(fields))
+	for key, value := range fields {
+		logrusFields[key] = value
+	}
+	return logrusFields
+}
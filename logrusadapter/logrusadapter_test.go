@@ -0,0 +1,37 @@
+package logrusadapter
+
+import (
+	"testing"
+
+	pkgErrors "github.com/pkg/errors"
+
+	"github.com/alexhornbake/extenderr"
+)
+
+func TestToLogrusFields(t *testing.T) {
+	err := extenderr.WithContext(pkgErrors.New("the root cause"), map[string]interface{}{"user_id": 666, "request_id": "abc"})
+
+	fields := ToLogrusFields(err)
+	if fields["user_id"] != 666 {
+		t.Errorf("expected user_id field to be 666, got %v", fields["user_id"])
+	}
+	if fields["request_id"] != "abc" {
+		t.Errorf("expected request_id field to be abc, got %v", fields["request_id"])
+	}
+}
+
+func TestToLogrusFields_nil(t *testing.T) {
+	if fields := ToLogrusFields(nil); len(fields) != 0 {
+		t.Errorf("expected no fields for a nil error, got %v", fields)
+	}
+}
+
+func TestToLogrusFields_outerWinsOnCollision(t *testing.T) {
+	err := extenderr.WithContext(pkgErrors.New("the root cause"), map[string]interface{}{"user_id": 666})
+	err = extenderr.WithField(err, "user_id", 42)
+
+	fields := ToLogrusFields(err)
+	if fields["user_id"] != 42 {
+		t.Errorf("expected the outer field to win on collision, got %v", fields["user_id"])
+	}
+}
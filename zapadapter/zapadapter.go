@@ -0,0 +1,22 @@
+// package zapadapter converts an extenderr error's context fields into the
+// []zap.Field a zap.Logger's structured logging calls (Error, With, ...) expect,
+// so callers don't have to hand-roll one zap.Any per field. Kept as a separate
+// package so the core extenderr package does not depend on zap.
+package zapadapter
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/alexhornbake/extenderr"
+)
+
+// ToZapFields returns the error's context fields (see extenderr.Fields) as zap.Field,
+// ready to pass to a zap.Logger call.
+func ToZapFields(err error) []zap.Field {
+	fields := extenderr.Fields(err)
+	zapFields := make([]zap.Field, 0, len(fields))
+	for key, value := range fields {
+		zapFields = append(zapFields, zap.Any(key, value))
+	}
+	return zapFields
+}
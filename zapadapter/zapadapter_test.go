@@ -0,0 +1,49 @@
+package zapadapter
+
+import (
+	"testing"
+
+	pkgErrors "github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/alexhornbake/extenderr"
+)
+
+// encode runs each field through a zapcore.ObjectEncoder so we can assert on
+// the value it produced rather than reaching into zap.Field's internals.
+func encode(fields []zap.Field) map[string]interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return enc.Fields
+}
+
+func TestToZapFields(t *testing.T) {
+	err := extenderr.WithContext(pkgErrors.New("the root cause"), map[string]interface{}{"user_id": 666, "request_id": "abc"})
+
+	got := encode(ToZapFields(err))
+	if got["user_id"] != int64(666) {
+		t.Errorf("expected user_id field to be 666, got %v", got["user_id"])
+	}
+	if got["request_id"] != "abc" {
+		t.Errorf("expected request_id field to be abc, got %v", got["request_id"])
+	}
+}
+
+func TestToZapFields_nil(t *testing.T) {
+	if fields := ToZapFields(nil); len(fields) != 0 {
+		t.Errorf("expected no fields for a nil error, got %v", fields)
+	}
+}
+
+func TestToZapFields_outerWinsOnCollision(t *testing.T) {
+	err := extenderr.WithContext(pkgErrors.New("the root cause"), map[string]interface{}{"user_id": 666})
+	err = extenderr.WithField(err, "user_id", 42)
+
+	got := encode(ToZapFields(err))
+	if got["user_id"] != int64(42) {
+		t.Errorf("expected the outer field to win on collision, got %v", got["user_id"])
+	}
+}